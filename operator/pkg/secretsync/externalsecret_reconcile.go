@@ -0,0 +1,152 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package secretsync
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	controllerruntime "github.com/cilium/cilium/operator/pkg/controller-runtime"
+	cesv2alpha1 "github.com/cilium/cilium/pkg/k8s/apis/cilium.io/v2alpha1"
+	"github.com/cilium/cilium/pkg/logging/logfields"
+)
+
+// defaultExternalSecretRefreshInterval is used when a CiliumExternalSecret
+// does not set Spec.RefreshInterval.
+const defaultExternalSecretRefreshInterval = 5 * time.Minute
+
+// ExternalSecretReconciler reconciles CiliumExternalSecret resources,
+// fetching their material from the configured SecretSource and writing it
+// into the destination namespace/name through the same
+// ensureSyncedSecret/cleanupSyncedSecret pipeline used for in-cluster
+// Secret sources.
+type ExternalSecretReconciler struct {
+	client client.Client
+	logger *logrus.Entry
+
+	// syncer provides the write-side pipeline (ensureSyncedSecret,
+	// cleanupSyncedSecret) shared with the in-cluster Secret syncer.
+	syncer *secretSyncer
+
+	// sources maps a CiliumExternalSecret's Spec.Provider to the backend
+	// that can fetch its material.
+	sources map[string]SecretSource
+}
+
+// NewExternalSecretReconciler creates an ExternalSecretReconciler that
+// resolves CiliumExternalSecret.Spec.Provider against sources and writes
+// mirrored Secrets using syncer's pipeline.
+func NewExternalSecretReconciler(c client.Client, logger *logrus.Entry, syncer *secretSyncer, sources map[string]SecretSource) *ExternalSecretReconciler {
+	return &ExternalSecretReconciler{
+		client:  c,
+		logger:  logger,
+		syncer:  syncer,
+		sources: sources,
+	}
+}
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *ExternalSecretReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	scopedLog := r.logger.WithFields(logrus.Fields{
+		logfields.Controller: "external-secret-syncer",
+		logfields.Resource:   req.NamespacedName,
+	})
+	scopedLog.Info("Syncing external secret")
+
+	es := &cesv2alpha1.CiliumExternalSecret{}
+	if err := r.client.Get(ctx, req.NamespacedName, es); err != nil {
+		if k8serrors.IsNotFound(err) {
+			if err := r.syncer.cleanupSyncedSecret(ctx, req, scopedLog); err != nil {
+				return controllerruntime.Fail(err)
+			}
+			return controllerruntime.Success()
+		}
+		return controllerruntime.Fail(err)
+	}
+
+	source, ok := r.sources[es.Spec.Provider]
+	if !ok {
+		err := fmt.Errorf("no SecretSource registered for provider %q", es.Spec.Provider)
+		r.recordStatus(ctx, es, err, scopedLog)
+		return controllerruntime.Fail(err)
+	}
+
+	material, err := source.Get(ctx, SecretRef{Provider: es.Spec.Provider, Path: es.Spec.Path})
+	if err != nil {
+		r.recordStatus(ctx, es, err, scopedLog)
+		return controllerruntime.Fail(err)
+	}
+
+	desired := desiredExternalSyncSecret(es, material)
+
+	requeueAfter, err := r.syncer.ensureSyncedSecret(ctx, es, desired, scopedLog)
+	if err != nil {
+		r.recordStatus(ctx, es, err, scopedLog)
+		return controllerruntime.Fail(err)
+	}
+	if requeueAfter > 0 {
+		// Nothing was written yet; the existing mirror is still terminating.
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+	r.recordStatus(ctx, es, nil, scopedLog)
+
+	scopedLog.Info("Successfully synced external secret")
+	return ctrl.Result{RequeueAfter: refreshInterval(es)}, nil
+}
+
+// desiredExternalSyncSecret builds the Secret that should exist at
+// es.Spec.DestinationNamespace/DestinationName for the given material.
+func desiredExternalSyncSecret(es *cesv2alpha1.CiliumExternalSecret, material SecretMaterial) *corev1.Secret {
+	s := &corev1.Secret{}
+	s.SetNamespace(es.Spec.DestinationNamespace)
+	s.SetName(es.Spec.DestinationName)
+	s.SetLabels(map[string]string{
+		OwningSecretNamespace: es.Namespace,
+		OwningSecretName:      es.Name,
+		OwningKind:            OwningKindCiliumExternalSecret,
+	})
+	s.Data = material.Data
+	s.Type = material.Type
+
+	return s
+}
+
+// recordStatus updates es.Status to reflect the outcome of the most recent
+// sync attempt.
+func (r *ExternalSecretReconciler) recordStatus(ctx context.Context, es *cesv2alpha1.CiliumExternalSecret, syncErr error, scopedLog *logrus.Entry) {
+	if syncErr != nil {
+		es.Status.LastSyncError = syncErr.Error()
+	} else {
+		now := metav1.Now()
+		es.Status.LastSyncTime = &now
+		es.Status.LastSyncError = ""
+	}
+
+	if err := r.client.Status().Update(ctx, es); err != nil {
+		scopedLog.WithError(err).Warn("Failed to update CiliumExternalSecret status")
+	}
+}
+
+func refreshInterval(es *cesv2alpha1.CiliumExternalSecret) time.Duration {
+	if es.Spec.RefreshInterval != nil {
+		return es.Spec.RefreshInterval.Duration
+	}
+	return defaultExternalSecretRefreshInterval
+}
+
+// SetupWithManager registers the ExternalSecretReconciler with mgr.
+func (r *ExternalSecretReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&cesv2alpha1.CiliumExternalSecret{}).
+		Complete(r)
+}
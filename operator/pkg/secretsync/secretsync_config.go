@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package secretsync
+
+import (
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// SecretSyncConfig configures the concurrency and retry behaviour of the
+// secret-sync controller.
+type SecretSyncConfig struct {
+	// SecretsSyncMaxConcurrentReconciles is the maximum number of
+	// concurrent Reconcile calls the secret-sync controller will run.
+	SecretsSyncMaxConcurrentReconciles int
+
+	// SecretsSyncRequeueInterval is the initial delay before retrying a
+	// reconcile that is blocked on a terminating synced secret. The delay
+	// doubles the longer the secret stays terminating, up to
+	// SecretsSyncRateLimiterSlowDelay.
+	SecretsSyncRequeueInterval time.Duration
+
+	// SecretsSyncRateLimiterFastDelay is the requeue delay used for the
+	// first SecretsSyncRateLimiterFastAttempts retries of a failing item.
+	SecretsSyncRateLimiterFastDelay time.Duration
+
+	// SecretsSyncRateLimiterFastAttempts is the number of retries a
+	// failing item gets at SecretsSyncRateLimiterFastDelay before falling
+	// back to SecretsSyncRateLimiterSlowDelay.
+	SecretsSyncRateLimiterFastAttempts int
+
+	// SecretsSyncRateLimiterSlowDelay is the requeue delay used once an
+	// item has exhausted its fast retries.
+	SecretsSyncRateLimiterSlowDelay time.Duration
+
+	// SecretsSyncUpdateOnly, when enabled, makes the syncer refuse to
+	// reconcile a synced secret whose Immutable or Type field differs from
+	// the desired one - fields Kubernetes forbids mutating in place -
+	// instead of deleting and recreating it, which would leave a window
+	// where the mirrored secret is absent.
+	SecretsSyncUpdateOnly bool
+
+	// SecretsSyncGCInterval is how often the orphaned-secret garbage
+	// collector sweeps, in addition to the sweep it always runs on
+	// startup. Zero disables the periodic sweep.
+	SecretsSyncGCInterval time.Duration
+
+	// SecretsSyncGCGracePeriod is how long a synced secret must be
+	// continuously observed as orphaned before the garbage collector
+	// deletes it, to tolerate brief informer-cache inconsistencies.
+	SecretsSyncGCGracePeriod time.Duration
+}
+
+// DefaultSecretSyncConfig is the configuration used when the operator does
+// not override any of the secret-sync flags.
+var DefaultSecretSyncConfig = SecretSyncConfig{
+	SecretsSyncMaxConcurrentReconciles: 1,
+	SecretsSyncRequeueInterval:         defaultRequeueInterval,
+	SecretsSyncRateLimiterFastDelay:    1 * time.Second,
+	SecretsSyncRateLimiterFastAttempts: 5,
+	SecretsSyncRateLimiterSlowDelay:    60 * time.Second,
+	SecretsSyncGCInterval:              10 * time.Minute,
+	SecretsSyncGCGracePeriod:           5 * time.Minute,
+}
+
+// Flags registers the secret-sync controller flags onto the given flag set.
+func (cfg SecretSyncConfig) Flags(flags *pflag.FlagSet) {
+	flags.Int("secrets-sync-max-concurrent-reconciles", cfg.SecretsSyncMaxConcurrentReconciles, "Max number of concurrent reconciles for the secret-sync controller")
+	flags.Duration("secrets-sync-requeue-interval", cfg.SecretsSyncRequeueInterval, "Initial requeue interval used while waiting for a terminating synced secret to be removed; it grows up to the slow rate-limiter delay")
+	flags.Duration("secrets-sync-rate-limiter-fast-delay", cfg.SecretsSyncRateLimiterFastDelay, "Requeue delay applied to the first retries of a failing secret-sync item")
+	flags.Int("secrets-sync-rate-limiter-fast-attempts", cfg.SecretsSyncRateLimiterFastAttempts, "Number of retries a failing secret-sync item gets at the fast requeue delay before switching to the slow delay")
+	flags.Duration("secrets-sync-rate-limiter-slow-delay", cfg.SecretsSyncRateLimiterSlowDelay, "Requeue delay applied once a secret-sync item has exhausted its fast retries")
+	flags.Bool("secrets-sync-update-only", cfg.SecretsSyncUpdateOnly, "Refuse to delete and recreate a synced secret when its Type or Immutable field would need to change, surfacing an error/event instead")
+	flags.Duration("secrets-sync-gc-interval", cfg.SecretsSyncGCInterval, "Interval between periodic sweeps for orphaned synced secrets, in addition to the sweep always run on startup; 0 disables the periodic sweep")
+	flags.Duration("secrets-sync-gc-grace-period", cfg.SecretsSyncGCGracePeriod, "How long a synced secret must be continuously observed as orphaned before it is garbage collected")
+}
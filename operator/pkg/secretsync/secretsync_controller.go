@@ -0,0 +1,242 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package secretsync
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+)
+
+const (
+	// OwningSecretNamespace is the label key on a synced secret that records
+	// the namespace of the original secret it was mirrored from.
+	OwningSecretNamespace = "secretsync.cilium.io/owning-secret-namespace"
+
+	// OwningSecretName is the label key on a synced secret that records the
+	// name of the original secret it was mirrored from.
+	OwningSecretName = "secretsync.cilium.io/owning-secret-name"
+
+	// OwningKind is the label key on a synced secret that records the kind
+	// of the resource named by OwningSecretNamespace/OwningSecretName -
+	// either a corev1.Secret or a CiliumExternalSecret - so the garbage
+	// collector knows which API to check when deciding whether the owner
+	// still exists. A synced secret with no OwningKind label is treated as
+	// owned by a corev1.Secret, for compatibility with mirrors created
+	// before this label was introduced.
+	OwningKind = "secretsync.cilium.io/owning-kind"
+
+	// OwningKindSecret is the OwningKind value for mirrors sourced from an
+	// in-cluster corev1.Secret.
+	OwningKindSecret = "Secret"
+
+	// OwningKindCiliumExternalSecret is the OwningKind value for mirrors
+	// sourced from a CiliumExternalSecret.
+	OwningKindCiliumExternalSecret = "CiliumExternalSecret"
+
+	// SecretSyncFinalizer is the finalizer the secret syncer adds to synced
+	// secrets it wants to be notified about before they are fully removed.
+	// It is only ever added/removed by this controller.
+	SecretSyncFinalizer = "secretsync.cilium.io/finalizer"
+
+	// DeleteGraceStartAnnotation records when the garbage collector first
+	// observed a synced secret as orphaned. The secret is only deleted once
+	// this timestamp is older than SecretsSyncGCGracePeriod, so a transient
+	// informer-cache inconsistency cannot cause data loss.
+	DeleteGraceStartAnnotation = "secretsync.cilium.io/delete-grace-start"
+
+	// defaultRequeueInterval is used when a secretSyncer is constructed
+	// without an explicit requeue interval.
+	defaultRequeueInterval = 2 * time.Second
+)
+
+// mainObjectReferencedFunc returns whether the given Secret is still
+// referenced by the main object(s) (e.g. Ingress, Gateway) that the syncer is
+// mirroring secrets on behalf of.
+type mainObjectReferencedFunc func(ctx context.Context, c client.Client, secret *corev1.Secret) bool
+
+// secretSyncer syncs secrets referenced by a main object (e.g. Ingress,
+// Gateway) from their original namespace into one or more target namespaces,
+// so that they can be consumed by components (e.g. Envoy) which are only
+// granted access to those namespaces.
+type secretSyncer struct {
+	client client.Client
+	logger *logrus.Entry
+
+	// secretsNamespace is the namespace given to the constructor, which
+	// seeds targetNamespaces as its only entry until WithTargetNamespaces or
+	// WithNamespaceSelector replaces it.
+	secretsNamespace string
+
+	// targetNamespaces is the static list of namespaces to mirror secrets
+	// into. Mutually exclusive with namespaceSelector.
+	targetNamespaces []string
+
+	// namespaceSelector, if set, dynamically selects the target namespaces
+	// to mirror secrets into by matching namespace labels. Mutually
+	// exclusive with targetNamespaces.
+	namespaceSelector *metav1.LabelSelector
+
+	// mainObjectReferencedFunc reports whether a given Secret is still
+	// referenced by a main object and should therefore continue to be
+	// synced.
+	mainObjectReferencedFunc mainObjectReferencedFunc
+
+	// requeueInterval is the initial delay before retrying a reconcile that
+	// is blocked on a terminating synced secret; see terminatingBackoff.
+	requeueInterval time.Duration
+
+	// config holds the concurrency and rate-limiting knobs for this
+	// controller.
+	config SecretSyncConfig
+
+	// recorder records events against the original Secret, e.g. when
+	// update-only mode refuses to reconcile a synced secret.
+	recorder record.EventRecorder
+
+	// metrics holds the Prometheus metrics emitted by this controller.
+	metrics *Metrics
+}
+
+// NewSecretSyncReconciler creates a new secretSyncer that mirrors secrets
+// into secretsNamespace, using the default concurrency and rate-limiting
+// configuration.
+func NewSecretSyncReconciler(c client.Client, logger *logrus.Entry, secretsNamespace string, fn mainObjectReferencedFunc) *secretSyncer {
+	return NewSecretSyncReconcilerWithConfig(c, logger, secretsNamespace, fn, DefaultSecretSyncConfig)
+}
+
+// NewSecretSyncReconcilerWithConfig is like NewSecretSyncReconciler but
+// allows overriding the concurrency and rate-limiting configuration, e.g.
+// from operator flags.
+func NewSecretSyncReconcilerWithConfig(c client.Client, logger *logrus.Entry, secretsNamespace string, fn mainObjectReferencedFunc, cfg SecretSyncConfig) *secretSyncer {
+	return &secretSyncer{
+		client:                   c,
+		logger:                   logger,
+		secretsNamespace:         secretsNamespace,
+		targetNamespaces:         []string{secretsNamespace},
+		mainObjectReferencedFunc: fn,
+		requeueInterval:          cfg.SecretsSyncRequeueInterval,
+		config:                   cfg,
+		metrics:                  NewMetrics(prometheus.NewRegistry()),
+	}
+}
+
+// WithMetrics registers this controller's metrics with m instead of the
+// private registry it defaults to, e.g. so they are served from the
+// operator's shared /metrics endpoint.
+func (r *secretSyncer) WithMetrics(m *Metrics) *secretSyncer {
+	r.metrics = m
+	return r
+}
+
+// WithRequeueInterval overrides the default interval used to requeue
+// reconciles that are waiting for a terminating synced secret to be removed.
+func (r *secretSyncer) WithRequeueInterval(d time.Duration) *secretSyncer {
+	r.requeueInterval = d
+	return r
+}
+
+// WithEventRecorder sets the recorder used to surface update-only mode
+// refusals as events on the original Secret.
+func (r *secretSyncer) WithEventRecorder(recorder record.EventRecorder) *secretSyncer {
+	r.recorder = recorder
+	return r
+}
+
+// WithTargetNamespaces replaces secretsNamespace with an explicit, static
+// list of namespaces to mirror secrets into. Clears any namespaceSelector.
+func (r *secretSyncer) WithTargetNamespaces(namespaces ...string) *secretSyncer {
+	r.targetNamespaces = namespaces
+	r.namespaceSelector = nil
+	return r
+}
+
+// WithNamespaceSelector makes the syncer dynamically resolve target
+// namespaces on every reconcile by matching namespace labels against sel,
+// instead of using a static list. Clears any explicit targetNamespaces.
+func (r *secretSyncer) WithNamespaceSelector(sel *metav1.LabelSelector) *secretSyncer {
+	r.namespaceSelector = sel
+	r.targetNamespaces = nil
+	return r
+}
+
+// resolveTargets returns the current set of namespaces a secret should be
+// mirrored into, evaluating namespaceSelector against the live cluster state
+// when one is configured.
+func (r *secretSyncer) resolveTargets(ctx context.Context) ([]string, error) {
+	if r.namespaceSelector == nil {
+		return r.targetNamespaces, nil
+	}
+
+	sel, err := metav1.LabelSelectorAsSelector(r.namespaceSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	var namespaces corev1.NamespaceList
+	if err := r.client.List(ctx, &namespaces, client.MatchingLabelsSelector{Selector: sel}); err != nil {
+		return nil, err
+	}
+
+	targets := make([]string, 0, len(namespaces.Items))
+	for _, ns := range namespaces.Items {
+		targets = append(targets, ns.Name)
+	}
+	return targets, nil
+}
+
+// rateLimiter builds the ItemFastSlowRateLimiter used by the controller's
+// work queue: failing items are retried quickly for the first
+// SecretsSyncRateLimiterFastAttempts tries, then fall back to a much slower
+// retry interval so a persistently failing secret doesn't spin the queue.
+func (r *secretSyncer) rateLimiter() workqueue.RateLimiter {
+	return workqueue.NewItemFastSlowRateLimiter(
+		r.config.SecretsSyncRateLimiterFastDelay,
+		r.config.SecretsSyncRateLimiterSlowDelay,
+		r.config.SecretsSyncRateLimiterFastAttempts,
+	)
+}
+
+// SetupWithManager registers the secretSyncer with mgr, running up to
+// config.SecretsSyncMaxConcurrentReconciles Reconcile calls in parallel and
+// using an ItemFastSlowRateLimiter for failing items.
+func (r *secretSyncer) SetupWithManager(mgr ctrl.Manager) error {
+	if r.recorder == nil {
+		r.recorder = mgr.GetEventRecorderFor("secret-syncer")
+	}
+
+	if err := mgr.Add(&gcRunnable{r: r}); err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Secret{}).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: r.config.SecretsSyncMaxConcurrentReconciles,
+			RateLimiter:             r.rateLimiter(),
+			NewQueue:                r.newQueue,
+		}).
+		Complete(r)
+}
+
+// newQueue builds the rate-limiting work queue used by this controller,
+// reporting its depth through r.metrics.QueueDepth. It is passed as
+// controller.Options.NewQueue rather than calling workqueue.SetProvider, so
+// it only scopes metrics to this controller's own queue instead of
+// overriding every work queue's metrics provider in the operator process.
+func (r *secretSyncer) newQueue(controllerName string, rateLimiter workqueue.RateLimiter) workqueue.RateLimitingInterface {
+	return workqueue.NewRateLimitingQueueWithConfig(rateLimiter, workqueue.RateLimitingQueueConfig{
+		Name:            controllerName,
+		MetricsProvider: queueMetricsProvider{metrics: r.metrics},
+	})
+}
@@ -0,0 +1,187 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package secretsync
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	cesv2alpha1 "github.com/cilium/cilium/pkg/k8s/apis/cilium.io/v2alpha1"
+	"github.com/cilium/cilium/pkg/logging/logfields"
+)
+
+// gcRunnable sweeps r.secretsNamespace (and, more generally, every namespace
+// holding synced secrets) for synced secrets whose source Secret is gone or
+// no longer referenced, on startup and - if configured - on an interval
+// after that. This closes the gap where a source Secret is deleted while the
+// operator is down and no Reconcile fires for it once the operator comes
+// back up.
+type gcRunnable struct {
+	r *secretSyncer
+}
+
+// Start implements manager.Runnable.
+func (g *gcRunnable) Start(ctx context.Context) error {
+	scopedLog := g.r.logger.WithField(logfields.Controller, "secret-syncer-gc")
+
+	if err := g.r.sweepOrphanedSecrets(ctx, scopedLog); err != nil {
+		scopedLog.WithError(err).Error("Initial orphaned synced secret sweep failed")
+	}
+
+	if g.r.config.SecretsSyncGCInterval <= 0 {
+		return nil
+	}
+
+	ticker := time.NewTicker(g.r.config.SecretsSyncGCInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := g.r.sweepOrphanedSecrets(ctx, scopedLog); err != nil {
+				scopedLog.WithError(err).Error("Periodic orphaned synced secret sweep failed")
+			}
+		}
+	}
+}
+
+var _ manager.Runnable = (*gcRunnable)(nil)
+
+// ownedSecretsRequirement selects every secret carrying both ownership
+// labels, regardless of their value.
+func ownedSecretsRequirement() (labels.Selector, error) {
+	sel := labels.NewSelector()
+	for _, key := range []string{OwningSecretNamespace, OwningSecretName} {
+		req, err := labels.NewRequirement(key, selection.Exists, nil)
+		if err != nil {
+			return nil, err
+		}
+		sel = sel.Add(*req)
+	}
+	return sel, nil
+}
+
+// sweepOrphanedSecrets lists every synced secret across all namespaces and
+// deletes the ones whose source Secret is gone or no longer referenced,
+// after they've been observed as orphaned for at least
+// config.SecretsSyncGCGracePeriod.
+func (r *secretSyncer) sweepOrphanedSecrets(ctx context.Context, scopedLog *logrus.Entry) error {
+	sel, err := ownedSecretsRequirement()
+	if err != nil {
+		return err
+	}
+
+	var candidates corev1.SecretList
+	if err := r.client.List(ctx, &candidates, client.MatchingLabelsSelector{Selector: sel}); err != nil {
+		return err
+	}
+
+	for i := range candidates.Items {
+		secret := &candidates.Items[i]
+		if err := r.reconcileOrphanCandidate(ctx, secret, scopedLog); err != nil {
+			scopedLog.WithError(err).WithField(logfields.Resource, client.ObjectKeyFromObject(secret)).
+				Warn("Failed to garbage collect synced secret candidate")
+		}
+	}
+
+	return nil
+}
+
+// reconcileOrphanCandidate decides what, if anything, to do about a single
+// synced secret found during a sweep: heal it if its source is still valid,
+// start its grace period if it was just found orphaned, or delete it if the
+// grace period has elapsed.
+func (r *secretSyncer) reconcileOrphanCandidate(ctx context.Context, secret *corev1.Secret, scopedLog *logrus.Entry) error {
+	referenced, err := r.sourceStillReferenced(ctx, secret)
+	if err != nil {
+		return err
+	}
+
+	if referenced {
+		return r.clearGraceStart(ctx, secret)
+	}
+
+	graceStart, ok := secret.Annotations[DeleteGraceStartAnnotation]
+	if !ok {
+		return r.stampGraceStart(ctx, secret)
+	}
+
+	startedAt, err := time.Parse(time.RFC3339, graceStart)
+	if err != nil {
+		// Malformed annotation: treat as just discovered rather than failing
+		// the sweep.
+		return r.stampGraceStart(ctx, secret)
+	}
+
+	if time.Since(startedAt) < r.config.SecretsSyncGCGracePeriod {
+		return nil
+	}
+
+	scopedLog.WithField(logfields.Resource, client.ObjectKeyFromObject(secret)).
+		Info("Deleting synced secret orphaned past its grace period")
+	return r.deleteSyncedSecret(ctx, secret)
+}
+
+// sourceStillReferenced reports whether the owner of secret - a corev1.Secret
+// or a CiliumExternalSecret, as recorded by its OwningKind label - still
+// exists and, for Secret owners, is still referenced by a main object.
+func (r *secretSyncer) sourceStillReferenced(ctx context.Context, secret *corev1.Secret) (bool, error) {
+	owningNamespace := secret.Labels[OwningSecretNamespace]
+	owningName := secret.Labels[OwningSecretName]
+	if owningNamespace == "" || owningName == "" {
+		// Not one of ours to manage; leave it alone.
+		return true, nil
+	}
+
+	if secret.Labels[OwningKind] == OwningKindCiliumExternalSecret {
+		es := &cesv2alpha1.CiliumExternalSecret{}
+		err := r.client.Get(ctx, client.ObjectKey{Namespace: owningNamespace, Name: owningName}, es)
+		switch {
+		case k8serrors.IsNotFound(err):
+			return false, nil
+		case err != nil:
+			return false, err
+		}
+		return true, nil
+	}
+
+	original := &corev1.Secret{}
+	err := r.client.Get(ctx, client.ObjectKey{Namespace: owningNamespace, Name: owningName}, original)
+	switch {
+	case k8serrors.IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	return r.mainObjectReferencedFunc(ctx, r.client, original), nil
+}
+
+func (r *secretSyncer) stampGraceStart(ctx context.Context, secret *corev1.Secret) error {
+	updated := secret.DeepCopy()
+	if updated.Annotations == nil {
+		updated.Annotations = map[string]string{}
+	}
+	updated.Annotations[DeleteGraceStartAnnotation] = time.Now().Format(time.RFC3339)
+	return r.client.Update(ctx, updated)
+}
+
+func (r *secretSyncer) clearGraceStart(ctx context.Context, secret *corev1.Secret) error {
+	if _, ok := secret.Annotations[DeleteGraceStartAnnotation]; !ok {
+		return nil
+	}
+	updated := secret.DeepCopy()
+	delete(updated.Annotations, DeleteGraceStartAnnotation)
+	return r.client.Update(ctx, updated)
+}
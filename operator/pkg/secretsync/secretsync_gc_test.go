@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package secretsync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestSweepOrphanedSecrets_StampsGraceStartBeforeDeleting(t *testing.T) {
+	orphan := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "secrets-namespace",
+			Name:      "foo-bar",
+			Labels:    map[string]string{OwningSecretNamespace: "foo", OwningSecretName: "bar"},
+		},
+	}
+
+	r, fakeClient := newFakeSecretSyncer(t, orphan)
+	r.config.SecretsSyncGCGracePeriod = time.Hour
+
+	require.NoError(t, r.sweepOrphanedSecrets(context.Background(), logrus.NewEntry(logrus.New())))
+
+	var stamped corev1.Secret
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(orphan), &stamped))
+	assert.Contains(t, stamped.Annotations, DeleteGraceStartAnnotation)
+}
+
+func TestSweepOrphanedSecrets_DeletesAfterGracePeriodElapses(t *testing.T) {
+	orphan := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "secrets-namespace",
+			Name:      "foo-bar",
+			Labels:    map[string]string{OwningSecretNamespace: "foo", OwningSecretName: "bar"},
+			Annotations: map[string]string{
+				DeleteGraceStartAnnotation: time.Now().Add(-time.Hour).Format(time.RFC3339),
+			},
+		},
+	}
+
+	r, fakeClient := newFakeSecretSyncer(t, orphan)
+	r.config.SecretsSyncGCGracePeriod = time.Minute
+
+	require.NoError(t, r.sweepOrphanedSecrets(context.Background(), logrus.NewEntry(logrus.New())))
+
+	err := fakeClient.Get(context.Background(), client.ObjectKeyFromObject(orphan), &corev1.Secret{})
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestSweepOrphanedSecrets_HealsReferencedSecretAndClearsGraceStart(t *testing.T) {
+	source := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "bar"},
+	}
+	synced := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "secrets-namespace",
+			Name:      "foo-bar",
+			Labels:    map[string]string{OwningSecretNamespace: "foo", OwningSecretName: "bar"},
+			Annotations: map[string]string{
+				DeleteGraceStartAnnotation: time.Now().Format(time.RFC3339),
+			},
+		},
+	}
+
+	r, fakeClient := newFakeSecretSyncer(t, source, synced)
+
+	require.NoError(t, r.sweepOrphanedSecrets(context.Background(), logrus.NewEntry(logrus.New())))
+
+	var healed corev1.Secret
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(synced), &healed))
+	assert.NotContains(t, healed.Annotations, DeleteGraceStartAnnotation)
+}
@@ -5,11 +5,14 @@ package secretsync
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -30,6 +33,12 @@ func (r *secretSyncer) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Re
 	})
 	scopedLog.Info("Syncing secrets")
 
+	start := time.Now()
+	outcome := "success"
+	defer func() {
+		r.metrics.ReconcileDuration.WithLabelValues(outcome).Observe(time.Since(start).Seconds())
+	}()
+
 	original := &corev1.Secret{}
 	if err := r.client.Get(ctx, req.NamespacedName, original); err != nil {
 		if k8serrors.IsNotFound(err) {
@@ -37,39 +46,138 @@ func (r *secretSyncer) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Re
 
 			// Check if there's an existing synced secret for the deleted Secret
 			if err := r.cleanupSyncedSecret(ctx, req, scopedLog); err != nil {
+				outcome = "error"
 				return controllerruntime.Fail(err)
 			}
 
 			return controllerruntime.Success()
 		}
 
+		outcome = "error"
 		return controllerruntime.Fail(err)
 	}
 
+	if original.DeletionTimestamp != nil && isOwnedSyncedSecret(original) {
+		// req named one of our own synced mirrors directly (e.g. a user
+		// deleted it), rather than the source it was mirrored from.
+		// cleanupSyncedSecret below only looks up mirrors by the owning
+		// labels of req's namespace/name, which never matches a mirror's
+		// own identity, so it would never strip the finalizer we added and
+		// the mirror would stay Terminating indefinitely. Handle it here
+		// instead.
+		scopedLog.Debug("Synced secret was deleted directly, removing owned finalizers so it can finish deleting")
+		if err := r.removeOwnedFinalizers(ctx, original); err != nil {
+			outcome = "error"
+			return controllerruntime.Fail(err)
+		}
+		return controllerruntime.Success()
+	}
+
 	if !r.mainObjectReferencedFunc(ctx, r.client, original) {
 		// Check if there's an existing synced secret that should be deleted
 		if err := r.cleanupSyncedSecret(ctx, req, scopedLog); err != nil {
+			outcome = "error"
 			return controllerruntime.Fail(err)
 		}
 		return controllerruntime.Success()
 	}
 
-	desiredSync := desiredSyncSecret(r.secretsNamespace, original)
+	targets, err := r.resolveTargets(ctx)
+	if err != nil {
+		outcome = "error"
+		return controllerruntime.Fail(err)
+	}
+
+	requeueAfter, err := r.syncToTargets(ctx, targets, original, scopedLog)
+	if err != nil {
+		outcome = "error"
+		return controllerruntime.Fail(err)
+	}
 
-	if err := r.ensureSyncedSecret(ctx, desiredSync); err != nil {
+	if err := r.pruneStaleTargets(ctx, original, targets, scopedLog); err != nil {
+		outcome = "error"
 		return controllerruntime.Fail(err)
 	}
 
+	if requeueAfter > 0 {
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
 	scopedLog.Info("Successfully synced secrets")
 	return controllerruntime.Success()
 }
 
+// syncToTargets ensures a mirror of original exists in every target
+// namespace. Targets are synced concurrently, since they are independent of
+// each other, and their results are aggregated: the returned error joins any
+// per-target failures, and the returned requeue interval is the largest
+// requested by any target.
+func (r *secretSyncer) syncToTargets(ctx context.Context, targets []string, original *corev1.Secret, scopedLog *logrus.Entry) (time.Duration, error) {
+	var (
+		mu         sync.Mutex
+		errs       []error
+		maxRequeue time.Duration
+		wg         sync.WaitGroup
+	)
+
+	for _, target := range targets {
+		wg.Add(1)
+		go func(target string) {
+			defer wg.Done()
+
+			desired := desiredSyncSecret(target, original)
+			requeueAfter, err := r.ensureSyncedSecret(ctx, original, desired, scopedLog)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				r.metrics.ReconcileErrorsTotal.WithLabelValues(target).Inc()
+				errs = append(errs, fmt.Errorf("sync to namespace %q: %w", target, err))
+				return
+			}
+			if requeueAfter > maxRequeue {
+				maxRequeue = requeueAfter
+			}
+		}(target)
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return 0, errors.Join(errs...)
+	}
+	return maxRequeue, nil
+}
+
+// cleanupSyncedSecret deletes every mirror of req across all target
+// namespaces, wherever it was synced to in the past.
 func (r *secretSyncer) cleanupSyncedSecret(ctx context.Context, req reconcile.Request, scopedLog *logrus.Entry) error {
-	syncSecret := &corev1.Secret{}
-	if err := r.client.Get(ctx, types.NamespacedName{Namespace: r.secretsNamespace, Name: req.Namespace + "-" + req.Name}, syncSecret); err == nil {
-		// Try to delete existing synced secret
-		scopedLog.Debug("Delete synced secret")
-		if err := r.client.Delete(ctx, syncSecret); err != nil {
+	return r.deleteSyncedSecretsOwnedBy(ctx, req.Namespace, req.Name, scopedLog)
+}
+
+// pruneStaleTargets removes mirrors of original from any namespace that is no
+// longer part of currentTargets, e.g. because a namespace stopped matching
+// namespaceSelector.
+func (r *secretSyncer) pruneStaleTargets(ctx context.Context, original *corev1.Secret, currentTargets []string, scopedLog *logrus.Entry) error {
+	keep := make(map[string]struct{}, len(currentTargets))
+	for _, target := range currentTargets {
+		keep[target] = struct{}{}
+	}
+
+	var synced corev1.SecretList
+	if err := r.client.List(ctx, &synced, client.MatchingLabels{
+		OwningSecretNamespace: original.Namespace,
+		OwningSecretName:      original.Name,
+	}); err != nil {
+		return err
+	}
+
+	for i := range synced.Items {
+		s := &synced.Items[i]
+		if _, ok := keep[s.Namespace]; ok {
+			continue
+		}
+		scopedLog.WithField(logfields.K8sNamespace, s.Namespace).Debug("Deleting synced secret in namespace that no longer matches targets")
+		if err := r.deleteSyncedSecret(ctx, s); err != nil {
 			return err
 		}
 	}
@@ -77,6 +185,60 @@ func (r *secretSyncer) cleanupSyncedSecret(ctx context.Context, req reconcile.Re
 	return nil
 }
 
+// deleteSyncedSecretsOwnedBy deletes every synced secret labeled as owned by
+// originalNamespace/originalName, regardless of which target namespace it
+// lives in.
+func (r *secretSyncer) deleteSyncedSecretsOwnedBy(ctx context.Context, originalNamespace, originalName string, scopedLog *logrus.Entry) error {
+	var synced corev1.SecretList
+	if err := r.client.List(ctx, &synced, client.MatchingLabels{
+		OwningSecretNamespace: originalNamespace,
+		OwningSecretName:      originalName,
+	}); err != nil {
+		return err
+	}
+
+	for i := range synced.Items {
+		s := &synced.Items[i]
+		scopedLog.WithField(logfields.K8sNamespace, s.Namespace).Debug("Delete synced secret")
+		if err := r.deleteSyncedSecret(ctx, s); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteSyncedSecret deletes synced and, since it may carry
+// SecretSyncFinalizer, also strips the finalizers this controller owns so
+// the deletion actually completes instead of leaving synced stuck
+// terminating until some future Reconcile happens to observe it.
+func (r *secretSyncer) deleteSyncedSecret(ctx context.Context, synced *corev1.Secret) error {
+	// Strip the finalizers first: synced is not refreshed by Delete, so an
+	// Update of it after Delete would fail on a stale resourceVersion.
+	if err := r.removeOwnedFinalizers(ctx, synced); err != nil {
+		return err
+	}
+	if err := r.client.Delete(ctx, synced); err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// isOwnedSyncedSecret reports whether secret is itself one of our synced
+// mirrors - identified by carrying both ownership labels and
+// SecretSyncFinalizer - rather than a source Secret being mirrored.
+func isOwnedSyncedSecret(secret *corev1.Secret) bool {
+	if secret.Labels[OwningSecretNamespace] == "" || secret.Labels[OwningSecretName] == "" {
+		return false
+	}
+	for _, f := range secret.Finalizers {
+		if f == SecretSyncFinalizer {
+			return true
+		}
+	}
+	return false
+}
+
 func desiredSyncSecret(secretsNamespace string, original *corev1.Secret) *corev1.Secret {
 	s := &corev1.Secret{}
 	s.SetNamespace(secretsNamespace)
@@ -88,6 +250,8 @@ func desiredSyncSecret(secretsNamespace string, original *corev1.Secret) *corev1
 	}
 	s.Labels[OwningSecretNamespace] = original.Namespace
 	s.Labels[OwningSecretName] = original.Name
+	s.Labels[OwningKind] = OwningKindSecret
+	s.SetFinalizers([]string{SecretSyncFinalizer})
 	s.Immutable = original.Immutable
 	s.Data = original.Data
 	s.StringData = original.StringData
@@ -96,22 +260,138 @@ func desiredSyncSecret(secretsNamespace string, original *corev1.Secret) *corev1
 	return s
 }
 
-func (r *secretSyncer) ensureSyncedSecret(ctx context.Context, desired *corev1.Secret) error {
+// ensureSyncedSecret makes sure that desired exists in the cluster, either by
+// creating it, patching an existing mirror in place, or - if the existing
+// mirror is terminating (e.g. blocked on a finalizer) - by clearing the
+// finalizers we own and requeueing until it is fully removed, so that it can
+// be recreated from scratch.
+//
+// A diff on Immutable or Type - fields Kubernetes forbids mutating in place -
+// is applied by deleting the existing mirror and creating desired. In
+// SecretsSyncUpdateOnly mode such a diff is refused instead, since deleting
+// and recreating leaves a window where downstream consumers (e.g. Envoy SDS)
+// observe the secret as missing.
+//
+// original is the resource the sync is being performed on behalf of - a
+// corev1.Secret for in-cluster sources, or e.g. a CiliumExternalSecret for
+// external secret backends - and is used purely to attribute events.
+//
+// It returns a non-zero requeueAfter when the reconcile needs to be retried
+// because the existing synced secret has not finished terminating yet.
+func (r *secretSyncer) ensureSyncedSecret(ctx context.Context, original client.Object, desired *corev1.Secret, scopedLog *logrus.Entry) (time.Duration, error) {
 	existing := &corev1.Secret{}
 	if err := r.client.Get(ctx, client.ObjectKeyFromObject(desired), existing); err != nil {
 		if k8serrors.IsNotFound(err) {
-			return r.client.Create(ctx, desired)
+			return 0, r.client.Create(ctx, desired)
 		}
-		return err
+		return 0, err
+	}
+
+	if existing.DeletionTimestamp != nil {
+		scopedLog.Debug("Existing synced secret is terminating, removing owned finalizers and waiting for removal")
+		if err := r.removeOwnedFinalizers(ctx, existing); err != nil {
+			return 0, err
+		}
+		return r.terminatingBackoff(existing), nil
+	}
+
+	if requiresImmutableFieldChange(existing, desired) {
+		if r.config.SecretsSyncUpdateOnly {
+			err := fmt.Errorf("synced secret %s/%s would require changing an immutable field (type or immutability) and secrets-sync-update-only is enabled; remove/recreate it manually", existing.Namespace, existing.Name)
+			if r.recorder != nil {
+				r.recorder.Eventf(original, corev1.EventTypeWarning, "SecretSyncUpdateOnlyBlocked", "%s", err.Error())
+			}
+			return 0, err
+		}
+		scopedLog.Debug("Synced secret needs a Type or Immutable change, deleting and recreating it")
+		if err := r.deleteSyncedSecret(ctx, existing); err != nil {
+			return 0, err
+		}
+		if err := r.client.Create(ctx, desired); err != nil {
+			if k8serrors.IsAlreadyExists(err) {
+				// Still terminating behind a finalizer we don't own.
+				return r.terminatingBackoff(existing), nil
+			}
+			return 0, err
+		}
+		return 0, nil
 	}
 
 	temp := existing.DeepCopy()
 	temp.SetAnnotations(desired.GetAnnotations())
 	temp.SetLabels(desired.GetLabels())
+	temp.SetFinalizers(desired.GetFinalizers())
 	temp.Immutable = desired.Immutable
 	temp.Data = desired.Data
 	temp.StringData = desired.StringData
 	temp.Type = desired.Type
 
-	return r.client.Patch(ctx, temp, client.MergeFrom(existing))
-}
\ No newline at end of file
+	return 0, r.client.Patch(ctx, temp, client.MergeFrom(existing))
+}
+
+// terminatingBackoff returns how long to wait before retrying a reconcile
+// blocked on existing's removal, growing from requeueInterval up to
+// config.SecretsSyncRateLimiterSlowDelay the longer existing has been
+// terminating, so a secret stuck behind a foreign finalizer doesn't spin the
+// queue at a fixed, tight interval indefinitely.
+func (r *secretSyncer) terminatingBackoff(existing *corev1.Secret) time.Duration {
+	if existing.DeletionTimestamp == nil {
+		return r.requeueInterval
+	}
+
+	backoff := r.requeueInterval
+	if backoff <= 0 {
+		// A zero/negative requeueInterval can never grow by doubling;
+		// fall back to a sane minimum so this doesn't spin forever.
+		backoff = defaultRequeueInterval
+	}
+	elapsed := time.Since(existing.DeletionTimestamp.Time)
+	for elapsed > backoff && backoff < r.config.SecretsSyncRateLimiterSlowDelay {
+		backoff *= 2
+	}
+	if backoff > r.config.SecretsSyncRateLimiterSlowDelay {
+		backoff = r.config.SecretsSyncRateLimiterSlowDelay
+	}
+	return backoff
+}
+
+// requiresImmutableFieldChange reports whether moving existing to desired
+// would change a field Kubernetes does not allow mutating on an existing
+// Secret.
+func requiresImmutableFieldChange(existing, desired *corev1.Secret) bool {
+	if existing.Type != desired.Type {
+		return true
+	}
+	existingImmutable := existing.Immutable != nil && *existing.Immutable
+	desiredImmutable := desired.Immutable != nil && *desired.Immutable
+	return existingImmutable != desiredImmutable
+}
+
+// removeOwnedFinalizers strips any finalizers the secret syncer itself owns
+// from a terminating synced secret, so that it doesn't block its own
+// recreation indefinitely. Finalizers owned by other controllers are left
+// untouched.
+func (r *secretSyncer) removeOwnedFinalizers(ctx context.Context, existing *corev1.Secret) error {
+	finalizers := existing.GetFinalizers()
+
+	kept := finalizers[:0:0]
+	for _, f := range finalizers {
+		if f != SecretSyncFinalizer {
+			kept = append(kept, f)
+		}
+	}
+
+	if len(kept) == len(finalizers) {
+		// Nothing owned by us to remove.
+		return nil
+	}
+
+	updated := existing.DeepCopy()
+	updated.SetFinalizers(kept)
+
+	if err := r.client.Update(ctx, updated); err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
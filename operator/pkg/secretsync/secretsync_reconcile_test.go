@@ -0,0 +1,167 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package secretsync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newFakeSecretSyncer(t *testing.T, initObjs ...client.Object) (*secretSyncer, client.Client) {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(initObjs...).
+		Build()
+
+	r := NewSecretSyncReconciler(fakeClient, logrus.NewEntry(logrus.New()), "secrets-namespace", func(ctx context.Context, c client.Client, secret *corev1.Secret) bool {
+		return true
+	})
+	r.requeueInterval = time.Millisecond
+
+	return r, fakeClient
+}
+
+func TestEnsureSyncedSecret_TerminatingExisting(t *testing.T) {
+	now := metav1.Now()
+	terminating := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "secrets-namespace",
+			Name:              "foo-bar",
+			DeletionTimestamp: &now,
+			Finalizers:        []string{SecretSyncFinalizer, "other.io/finalizer"},
+		},
+	}
+
+	r, fakeClient := newFakeSecretSyncer(t, terminating)
+
+	desired := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "secrets-namespace",
+			Name:      "foo-bar",
+			Labels:    map[string]string{OwningSecretNamespace: "foo", OwningSecretName: "bar"},
+		},
+		Data: map[string][]byte{"tls.crt": []byte("cert")},
+	}
+
+	original := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "bar"}}
+
+	requeueAfter, err := r.ensureSyncedSecret(context.Background(), original, desired, logrus.NewEntry(logrus.New()))
+	require.NoError(t, err)
+	assert.Equal(t, r.requeueInterval, requeueAfter)
+
+	var updated corev1.Secret
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(desired), &updated))
+	assert.NotContains(t, updated.Finalizers, SecretSyncFinalizer)
+	assert.Contains(t, updated.Finalizers, "other.io/finalizer")
+}
+
+func TestEnsureSyncedSecret_RecreatesOnceTerminatingSecretIsGone(t *testing.T) {
+	r, fakeClient := newFakeSecretSyncer(t)
+
+	desired := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "secrets-namespace",
+			Name:      "foo-bar",
+			Labels:    map[string]string{OwningSecretNamespace: "foo", OwningSecretName: "bar"},
+		},
+		Data: map[string][]byte{"tls.crt": []byte("cert")},
+	}
+
+	original := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "bar"}}
+
+	requeueAfter, err := r.ensureSyncedSecret(context.Background(), original, desired, logrus.NewEntry(logrus.New()))
+	require.NoError(t, err)
+	assert.Zero(t, requeueAfter)
+
+	var created corev1.Secret
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(desired), &created))
+	assert.Equal(t, desired.Data, created.Data)
+}
+
+func TestSyncToTargets_FansOutToEveryTarget(t *testing.T) {
+	r, fakeClient := newFakeSecretSyncer(t)
+
+	original := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "bar"},
+		Data:       map[string][]byte{"tls.crt": []byte("cert")},
+	}
+
+	targets := []string{"ns-a", "ns-b", "ns-c"}
+	requeueAfter, err := r.syncToTargets(context.Background(), targets, original, logrus.NewEntry(logrus.New()))
+	require.NoError(t, err)
+	assert.Zero(t, requeueAfter)
+
+	for _, target := range targets {
+		var synced corev1.Secret
+		require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKey{Namespace: target, Name: "foo-bar"}, &synced))
+		assert.Equal(t, original.Data, synced.Data)
+	}
+}
+
+func TestPruneStaleTargets_RemovesMirrorsOutsideCurrentTargets(t *testing.T) {
+	stale := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns-old",
+			Name:      "foo-bar",
+			Labels:    map[string]string{OwningSecretNamespace: "foo", OwningSecretName: "bar"},
+		},
+	}
+	keep := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns-new",
+			Name:      "foo-bar",
+			Labels:    map[string]string{OwningSecretNamespace: "foo", OwningSecretName: "bar"},
+		},
+	}
+
+	r, fakeClient := newFakeSecretSyncer(t, stale, keep)
+
+	original := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "bar"}}
+
+	require.NoError(t, r.pruneStaleTargets(context.Background(), original, []string{"ns-new"}, logrus.NewEntry(logrus.New())))
+
+	err := fakeClient.Get(context.Background(), client.ObjectKeyFromObject(stale), &corev1.Secret{})
+	assert.True(t, errors.IsNotFound(err))
+
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(keep), &corev1.Secret{}))
+}
+
+func TestEnsureSyncedSecret_UpdateOnlyRefusesImmutableFieldChange(t *testing.T) {
+	existing := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "secrets-namespace", Name: "foo-bar"},
+		Type:       corev1.SecretTypeOpaque,
+	}
+
+	r, fakeClient := newFakeSecretSyncer(t, existing)
+	r.config.SecretsSyncUpdateOnly = true
+
+	desired := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "secrets-namespace", Name: "foo-bar"},
+		Type:       corev1.SecretTypeTLS,
+	}
+	original := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "bar"}}
+
+	_, err := r.ensureSyncedSecret(context.Background(), original, desired, logrus.NewEntry(logrus.New()))
+	require.Error(t, err)
+
+	var unchanged corev1.Secret
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(existing), &unchanged))
+	assert.Equal(t, corev1.SecretTypeOpaque, unchanged.Type)
+}
@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package secretsync
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/1Password/connect-sdk-go/connect"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// OnePasswordSource is a SecretSource backed by a 1Password Connect server.
+type OnePasswordSource struct {
+	client  connect.Client
+	vaultID string
+}
+
+// NewOnePasswordSource creates a SecretSource that reads items from vaultID
+// on the given 1Password Connect client.
+func NewOnePasswordSource(client connect.Client, vaultID string) *OnePasswordSource {
+	return &OnePasswordSource{
+		client:  client,
+		vaultID: vaultID,
+	}
+}
+
+// Get fetches the item with ID ref.Path and translates its fields into
+// SecretMaterial, keyed by field label; fields with an empty label or value
+// are skipped. An item with both a "tls.crt" and a "tls.key" field is
+// surfaced as a TLS secret, whatever its category; everything else as Opaque.
+func (s *OnePasswordSource) Get(ctx context.Context, ref SecretRef) (SecretMaterial, error) {
+	item, err := s.client.GetItem(ref.Path, s.vaultID)
+	if err != nil {
+		return SecretMaterial{}, fmt.Errorf("reading 1password item %q: %w", ref.Path, err)
+	}
+
+	data := make(map[string][]byte, len(item.Fields))
+	for _, field := range item.Fields {
+		if field.Label == "" || field.Value == "" {
+			continue
+		}
+		data[field.Label] = []byte(field.Value)
+	}
+
+	secretType := corev1.SecretTypeOpaque
+	if _, hasCert := data[corev1.TLSCertKey]; hasCert {
+		if _, hasKey := data[corev1.TLSPrivateKeyKey]; hasKey {
+			secretType = corev1.SecretTypeTLS
+		}
+	}
+
+	return SecretMaterial{Data: data, Type: secretType}, nil
+}
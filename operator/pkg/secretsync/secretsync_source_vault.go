@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package secretsync
+
+import (
+	"context"
+	"fmt"
+
+	vaultapi "github.com/hashicorp/vault/api"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// VaultKVSource is a SecretSource backed by a HashiCorp Vault KV version 2
+// secrets engine.
+type VaultKVSource struct {
+	client *vaultapi.Client
+
+	// mount is the KV v2 mount point, e.g. "secret".
+	mount string
+}
+
+// NewVaultKVSource creates a SecretSource that reads KV v2 secrets from
+// mount on the given Vault client.
+func NewVaultKVSource(client *vaultapi.Client, mount string) *VaultKVSource {
+	return &VaultKVSource{
+		client: client,
+		mount:  mount,
+	}
+}
+
+// Get fetches the secret at ref.Path from the KV v2 mount and translates its
+// fields into SecretMaterial, keyed by field name.
+func (s *VaultKVSource) Get(ctx context.Context, ref SecretRef) (SecretMaterial, error) {
+	secret, err := s.client.KVv2(s.mount).Get(ctx, ref.Path)
+	if err != nil {
+		return SecretMaterial{}, fmt.Errorf("reading vault kv v2 secret %q: %w", ref.Path, err)
+	}
+	if secret == nil {
+		return SecretMaterial{}, fmt.Errorf("vault kv v2 secret %q not found", ref.Path)
+	}
+
+	data := make(map[string][]byte, len(secret.Data))
+	for field, value := range secret.Data {
+		s, ok := value.(string)
+		if !ok {
+			return SecretMaterial{}, fmt.Errorf("vault kv v2 secret %q field %q is not a string", ref.Path, field)
+		}
+		data[field] = []byte(s)
+	}
+
+	return SecretMaterial{Data: data, Type: corev1.SecretTypeOpaque}, nil
+}
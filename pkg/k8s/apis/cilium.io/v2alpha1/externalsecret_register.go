@@ -0,0 +1,27 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package v2alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// CiliumExternalSecretKindDefinition is the kind name of the
+// CiliumExternalSecret CRD.
+const CiliumExternalSecretKindDefinition = "CiliumExternalSecret"
+
+// SchemeGroupVersion, SchemeBuilder and AddToScheme already exist for the
+// cilium.io/v2alpha1 group elsewhere in this package; CiliumExternalSecret
+// only needs to add itself to that existing SchemeBuilder.
+func init() {
+	SchemeBuilder.Register(addExternalSecretKnownTypes)
+}
+
+func addExternalSecretKnownTypes(scheme *runtime.Scheme) error {
+	scheme.AddKnownTypes(SchemeGroupVersion,
+		&CiliumExternalSecret{},
+		&CiliumExternalSecretList{},
+	)
+	return nil
+}
@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package v2alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// +genclient
+// +k8s:deepcopy-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:categories={cilium},singular="ciliumexternalsecret",path="ciliumexternalsecrets",scope="Namespaced",shortName={ces}
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// CiliumExternalSecret names a piece of secret material held by an external
+// secret backend (e.g. HashiCorp Vault, 1Password Connect) and describes
+// where it should be mirrored to as a regular Kubernetes Secret, so that it
+// can flow through the same sync pipeline used for in-cluster Secrets.
+type CiliumExternalSecret struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec is the desired state of the external secret mirror.
+	//
+	// +kubebuilder:validation:Required
+	Spec CiliumExternalSecretSpec `json:"spec"`
+
+	// Status is the most recently observed status of the external secret
+	// mirror. It is set by the operator, not by the user.
+	//
+	// +kubebuilder:validation:Optional
+	Status CiliumExternalSecretStatus `json:"status,omitempty"`
+}
+
+// CiliumExternalSecretSpec defines where the secret material comes from and
+// where it should be written to.
+type CiliumExternalSecretSpec struct {
+	// Provider is the name of the external secret backend to fetch the
+	// material from, e.g. "vault" or "1password".
+	//
+	// +kubebuilder:validation:Required
+	Provider string `json:"provider"`
+
+	// Path is the provider-specific identifier of the secret material, e.g.
+	// a Vault KV v2 path or a 1Password Connect item ID.
+	//
+	// +kubebuilder:validation:Required
+	Path string `json:"path"`
+
+	// DestinationNamespace is the namespace the mirrored Secret is written
+	// to.
+	//
+	// +kubebuilder:validation:Required
+	DestinationNamespace string `json:"destinationNamespace"`
+
+	// DestinationName is the name of the mirrored Secret.
+	//
+	// +kubebuilder:validation:Required
+	DestinationName string `json:"destinationName"`
+
+	// RefreshInterval is how often the operator re-fetches the secret
+	// material from the provider. Defaults to 5 minutes when unset.
+	//
+	// +kubebuilder:validation:Optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+}
+
+// CiliumExternalSecretStatus reports the outcome of the most recent sync
+// attempt.
+type CiliumExternalSecretStatus struct {
+	// LastSyncTime is when the mirrored Secret was last successfully
+	// written.
+	//
+	// +kubebuilder:validation:Optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// LastSyncError holds the error from the most recent failed sync
+	// attempt, if any. Cleared on the next successful sync.
+	//
+	// +kubebuilder:validation:Optional
+	LastSyncError string `json:"lastSyncError,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+// +kubebuilder:object:root=true
+
+// CiliumExternalSecretList is a list of CiliumExternalSecret objects.
+type CiliumExternalSecretList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []CiliumExternalSecret `json:"items"`
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *CiliumExternalSecret) DeepCopyObject() runtime.Object {
+	if in == nil {
+		return nil
+	}
+	out := new(CiliumExternalSecret)
+	*out = *in
+	out.ObjectMeta = *in.ObjectMeta.DeepCopy()
+	if in.Spec.RefreshInterval != nil {
+		d := *in.Spec.RefreshInterval
+		out.Spec.RefreshInterval = &d
+	}
+	if in.Status.LastSyncTime != nil {
+		t := *in.Status.LastSyncTime
+		out.Status.LastSyncTime = &t
+	}
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *CiliumExternalSecretList) DeepCopyObject() runtime.Object {
+	if in == nil {
+		return nil
+	}
+	out := new(CiliumExternalSecretList)
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		out.Items = make([]CiliumExternalSecret, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+	return out
+}
+
+// DeepCopyInto copies in into out.
+func (in *CiliumExternalSecret) DeepCopyInto(out *CiliumExternalSecret) {
+	*out = *(in.DeepCopyObject().(*CiliumExternalSecret))
+}